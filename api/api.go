@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+)
+
+// MinerAPI is the per-miner instance-management RPC surface this series
+// adds, served alongside (not instead of) the existing address/mining RPC
+// methods (AddAddress, ListAddress, StatesForMining, Start, Stop, ...).
+type MinerAPI interface {
+	// ImportAddress bulk-imports miner configurations, reporting a
+	// per-entry result so one malformed or duplicate record doesn't abort
+	// the rest of the batch.
+	ImportAddress(ctx context.Context, miners []dtypes.MinerInfo) ([]dtypes.ImportResult, error)
+
+	// PolicyGet and PolicySet read and update a miner's persisted
+	// production policy flags.
+	PolicyGet(ctx context.Context, addr address.Address) (dtypes.MinerPolicy, error)
+	PolicySet(ctx context.Context, addr address.Address, flag, value string) error
+
+	// WalletAddBackend, WalletListBackends, and WalletRemoveBackend manage
+	// a miner's ordered list of signing backends, the same list the mining
+	// loop dispatches to in order to sign blocks. WalletTestBackends
+	// checks that each configured backend can currently be reached.
+	WalletAddBackend(ctx context.Context, addr address.Address, backend dtypes.WalletBackend) error
+	WalletListBackends(ctx context.Context, addr address.Address) ([]dtypes.WalletBackend, error)
+	WalletRemoveBackend(ctx context.Context, addr address.Address, name string) error
+	WalletTestBackends(ctx context.Context, addr address.Address) ([]WalletTestResult, error)
+
+	// NodeAdd, NodeList, and NodeRemove manage a miner's list of remote
+	// full-node upstreams used for lite-mode operation.
+	NodeAdd(ctx context.Context, addr address.Address, node dtypes.NodeInfo) error
+	NodeList(ctx context.Context, addr address.Address) ([]dtypes.NodeInfo, error)
+	NodeRemove(ctx context.Context, addr address.Address, name string) error
+}
+
+// WalletTestResult reports whether a single configured wallet backend could
+// be reached, for `wallet test`.
+type WalletTestResult struct {
+	Name string
+	OK   bool
+	Err  string `json:",omitempty"`
+}
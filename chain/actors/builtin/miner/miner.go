@@ -0,0 +1,64 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"golang.org/x/xerrors"
+)
+
+// versionedProofTypes associates a network version, the one at which a
+// given actors version takes effect, with the proof set that actors version
+// registers. proofTypesByVersion below must stay sorted oldest-first.
+type versionedProofTypes struct {
+	fromVersion network.Version
+	types       map[abi.RegisteredSealProof]struct{}
+}
+
+var proofTypesByVersion = []versionedProofTypes{
+	{network.Version0, v0SupportedProofTypes},
+	{network.Version7, v2SupportedProofTypes},
+	{network.Version10, v3SupportedProofTypes},
+	{network.Version12, v4SupportedProofTypes},
+	{network.Version13, v5SupportedProofTypes},
+	{network.Version14, v6SupportedProofTypes},
+	{network.Version15, v7SupportedProofTypes},
+	{network.Version16, v8SupportedProofTypes},
+}
+
+// SupportedProofTypes returns the seal proof types that are valid for new
+// sector commitments at the given network version. Each network upgrade can
+// add (or retire) proof registrations, so callers must not assume the v0
+// (genesis) set stays valid forever: this looks up the proof set of the
+// newest actors version that is already in effect at nv, mirroring the same
+// per-upgrade ladder the node itself steps through.
+func SupportedProofTypes(nv network.Version) (map[abi.RegisteredSealProof]struct{}, error) {
+	for i := len(proofTypesByVersion) - 1; i >= 0; i-- {
+		if nv >= proofTypesByVersion[i].fromVersion {
+			return proofTypesByVersion[i].types, nil
+		}
+	}
+
+	return nil, xerrors.Errorf("no known proof types for network version %d", nv)
+}
+
+// IsSupportedSectorSize reports whether ssize corresponds to a seal proof
+// type that is supported at network version nv.
+func IsSupportedSectorSize(ssize abi.SectorSize, nv network.Version) (bool, error) {
+	types, err := SupportedProofTypes(nv)
+	if err != nil {
+		return false, xerrors.Errorf("getting supported proof types for nv%d: %w", nv, err)
+	}
+
+	for spt := range types {
+		size, err := spt.SectorSize()
+		if err != nil {
+			continue
+		}
+
+		if size == ssize {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,37 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestIsSupportedSectorSizeAcrossNetworkVersions(t *testing.T) {
+	cases := []struct {
+		name  string
+		ssize abi.SectorSize
+		nv    network.Version
+		want  bool
+	}{
+		{"v0 32GiB at genesis", 32 << 30, network.Version0, true},
+		{"v0 32GiB still valid just before the v1 upgrade", 32 << 30, network.Version6, true},
+		{"unsupported size at genesis", 3 << 30, network.Version0, false},
+		{"32GiB valid at the actors v3 upgrade", 32 << 30, network.Version10, true},
+		{"32GiB still valid at the actors v8 upgrade", 32 << 30, network.Version16, true},
+		{"32GiB still valid well past the actors v8 upgrade", 32 << 30, network.Version18, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := IsSupportedSectorSize(c.ssize, c.nv)
+			if err != nil {
+				t.Fatalf("IsSupportedSectorSize: %s", err)
+			}
+
+			if got != c.want {
+				t.Fatalf("IsSupportedSectorSize(%d, nv%d) = %v, want %v", c.ssize, c.nv, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+)
+
+var v0SupportedProofTypes = toProofSet(miner0.SupportedProofTypes)
+
+func toProofSet(m map[abi.RegisteredSealProof]struct{}) map[abi.RegisteredSealProof]struct{} {
+	out := make(map[abi.RegisteredSealProof]struct{}, len(m))
+	for spt := range m {
+		out[spt] = struct{}{}
+	}
+
+	return out
+}
@@ -0,0 +1,10 @@
+package miner
+
+import (
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+)
+
+// v2SupportedProofTypes is the proof set effective from network version 7
+// (the actors v2 upgrade) onward, when the v1 (and v1_1) seal proof
+// registrations were introduced.
+var v2SupportedProofTypes = toProofSet(miner2.SupportedProofTypes)
@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+)
+
+// v3SupportedProofTypes is the proof set effective from network version 10
+// (the actors v3 upgrade) onward.
+var v3SupportedProofTypes = toProofSet(miner3.SupportedProofTypes)
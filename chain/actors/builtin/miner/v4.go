@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/miner"
+)
+
+// v4SupportedProofTypes is the proof set effective from network version 12
+// (the actors v4 upgrade) onward.
+var v4SupportedProofTypes = toProofSet(miner4.SupportedProofTypes)
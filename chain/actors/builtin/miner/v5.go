@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/miner"
+)
+
+// v5SupportedProofTypes is the proof set effective from network version 13
+// (the actors v5 upgrade) onward.
+var v5SupportedProofTypes = toProofSet(miner5.SupportedProofTypes)
@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+)
+
+// v6SupportedProofTypes is the proof set effective from network version 14
+// (the actors v6 upgrade) onward.
+var v6SupportedProofTypes = toProofSet(miner6.SupportedProofTypes)
@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+)
+
+// v7SupportedProofTypes is the proof set effective from network version 15
+// (the actors v7 upgrade) onward.
+var v7SupportedProofTypes = toProofSet(miner7.SupportedProofTypes)
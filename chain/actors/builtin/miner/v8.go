@@ -0,0 +1,9 @@
+package miner
+
+import (
+	miner8 "github.com/filecoin-project/specs-actors/v8/actors/builtin/miner"
+)
+
+// v8SupportedProofTypes is the proof set effective from network version 16
+// (the actors v8 upgrade) onward.
+var v8SupportedProofTypes = toProofSet(miner8.SupportedProofTypes)
@@ -3,50 +3,21 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
 	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v2"
 
 	"github.com/filecoin-project/go-address"
-	"github.com/filecoin-project/go-state-types/abi"
-
-	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
 
 	lcli "github.com/filecoin-project/venus-miner/cli"
 )
 
-func isSupportedSectorSize(ssize abi.SectorSize) bool { // nolint
-	for spf := range miner0.SupportedProofTypes {
-		switch spf {
-		case abi.RegisteredSealProof_StackedDrg2KiBV1:
-			if ssize == 2048 {
-				return true
-			}
-		case abi.RegisteredSealProof_StackedDrg8MiBV1:
-			if ssize == 8<<20 {
-				return true
-			}
-		case abi.RegisteredSealProof_StackedDrg512MiBV1:
-			if ssize == 512<<20 {
-				return true
-			}
-		case abi.RegisteredSealProof_StackedDrg32GiBV1:
-			if ssize == 32<<30 {
-				return true
-			}
-		case abi.RegisteredSealProof_StackedDrg64GiBV1:
-			if ssize == 64<<30 {
-				return true
-			}
-		default:
-
-		}
-	}
-
-	return false
-}
-
 var addressCmd = &cli.Command{
 	Name:  "address",
 	Usage: "manage the miner address",
@@ -57,6 +28,9 @@ var addressCmd = &cli.Command{
 		startMiningCmd,
 		stopMiningCmd,
 		addCmd,
+		importCmd,
+		exportCmd,
+		policyCmd,
 	},
 }
 
@@ -116,7 +90,15 @@ var addCmd = &cli.Command{
 		},
 	},
 	Action: func(cctx *cli.Context) error {
-		mi := dtypes.MinerInfo{Id: cctx.String("id"), Name: cctx.String("name")}
+		mi := dtypes.MinerInfo{
+			Id:   cctx.String("id"),
+			Name: cctx.String("name"),
+			Policy: dtypes.MinerPolicy{
+				ConsiderWinningPost:     true,
+				ConsiderWindowPost:      true,
+				ConsiderBlockProduction: true,
+			},
+		}
 
 		addr, err := address.NewFromString(cctx.String("miner"))
 		if err != nil {
@@ -141,10 +123,122 @@ var addCmd = &cli.Command{
 	},
 }
 
+var importCmd = &cli.Command{
+	Name:      "import",
+	Usage:     "bulk import miner configurations from a JSON or YAML file",
+	ArgsUsage: "<file>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one file path")
+		}
+
+		miners, err := loadMinerInfos(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("reading %s: %w", cctx.Args().First(), err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		results, err := postApi.ImportAddress(cctx.Context, miners)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			if r.Err != "" {
+				fmt.Printf("%s: FAILED: %s\n", r.Addr, r.Err)
+				continue
+			}
+			fmt.Printf("%s: ok\n", r.Addr)
+		}
+
+		return nil
+	},
+}
+
+var exportCmd = &cli.Command{
+	Name:  "export",
+	Usage: "export the current miner configurations as JSON, to stdout or to the --out file",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "write to this file instead of stdout",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		miners, err := postApi.ListAddress(cctx.Context, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		formatJson, err := json.MarshalIndent(miners, "", "\t")
+		if err != nil {
+			return err
+		}
+
+		out := cctx.String("out")
+		if out == "" {
+			fmt.Println(string(formatJson))
+			return nil
+		}
+
+		if err := ioutil.WriteFile(out, formatJson, 0644); err != nil {
+			return err
+		}
+
+		fmt.Printf("exported %d miners to %s\n", len(miners), out)
+		return nil
+	},
+}
+
+// loadMinerInfos reads a JSON or YAML array of dtypes.MinerInfo from path,
+// picking the format by file extension (.yaml/.yml vs everything else).
+func loadMinerInfos(path string) ([]dtypes.MinerInfo, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var miners []dtypes.MinerInfo
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		if err := yaml.Unmarshal(raw, &miners); err != nil {
+			return nil, err
+		}
+		return miners, nil
+	}
+
+	if err := json.Unmarshal(raw, &miners); err != nil {
+		return nil, err
+	}
+
+	return miners, nil
+}
+
 var listCmd = &cli.Command{
 	Name:  "list",
 	Usage: "print miners",
-	Flags: []cli.Flag{},
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:     "skip",
+			Required: false,
+		},
+		&cli.Int64Flag{
+			Name:     "limit",
+			Required: false,
+		},
+	},
 	Action: func(cctx *cli.Context) error {
 		postApi, closer, err := lcli.GetMinerAPI(cctx)
 		if err != nil {
@@ -152,7 +246,7 @@ var listCmd = &cli.Command{
 		}
 		defer closer()
 
-		miners, err := postApi.ListAddress(cctx.Context)
+		miners, err := postApi.ListAddress(cctx.Context, cctx.Int64("skip"), cctx.Int64("limit"))
 		if err != nil {
 			return err
 		}
@@ -269,3 +363,78 @@ var stopMiningCmd = &cli.Command{
 		return nil
 	},
 }
+
+var policyCmd = &cli.Command{
+	Name:  "policy",
+	Usage: "get or set a miner's persisted production policy flags",
+	Subcommands: []*cli.Command{
+		policyGetCmd,
+		policySetCmd,
+	},
+}
+
+var policyGetCmd = &cli.Command{
+	Name:      "get",
+	Usage:     "print a miner's policy flags",
+	ArgsUsage: "<miner address>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		policy, err := postApi.PolicyGet(cctx.Context, minerAddr)
+		if err != nil {
+			return err
+		}
+
+		formatJson, err := json.MarshalIndent(policy, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(formatJson))
+		return nil
+	},
+}
+
+// policySetCmd sets one policy flag at a time rather than taking a whole
+// policy struct, so `address policy set <miner> pause-until 123456` reads
+// the same way as the flag it's toggling.
+var policySetCmd = &cli.Command{
+	Name:      "set",
+	Usage:     "set one policy flag for a miner: consider-winning-post, consider-window-post, consider-block-production, pause-until",
+	ArgsUsage: "<miner address> <flag> <value>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 3 {
+			return xerrors.Errorf("must pass a miner address, a flag name, and a value")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().Get(0))
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err := postApi.PolicySet(cctx.Context, minerAddr, cctx.Args().Get(1), cctx.Args().Get(2)); err != nil {
+			return err
+		}
+
+		fmt.Println("set policy success.")
+		return nil
+	},
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+
+	"github.com/filecoin-project/venus-miner/chain/actors/builtin/miner"
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+	"github.com/filecoin-project/venus-miner/node/modules/nodeprovider"
+
+	lcli "github.com/filecoin-project/venus-miner/cli"
+)
+
+var nodeCmd = &cli.Command{
+	Name:  "node",
+	Usage: "manage the remote full-node upstreams a miner dispatches to in lite mode",
+	Subcommands: []*cli.Command{
+		nodeAddCmd,
+		nodeListCmd,
+		nodeRemoveCmd,
+	},
+}
+
+var nodeAddCmd = &cli.Command{
+	Name:      "add",
+	Usage:     "add a remote full-node upstream to a miner's round-robin list",
+	ArgsUsage: "<miner address>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "label for this upstream",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "listen-api",
+			Usage:    "multiaddr/URL of the remote node API",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name: "token",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		node := dtypes.NodeInfo{
+			Name: cctx.String("name"),
+			APIInfo: dtypes.APIInfo{
+				ListenAPI: cctx.String("listen-api"),
+				Token:     cctx.String("token"),
+			},
+		}
+
+		// Dial the node directly to validate it can actually serve this
+		// miner before persisting it: the manage daemon has no chain
+		// connection of its own in lite mode, so this is the only point at
+		// which we can check the sector size against the node's network
+		// version.
+		np, npCloser, err := nodeprovider.DialRemote(cctx.Context, node.Name, node.APIInfo)
+		if err != nil {
+			return xerrors.Errorf("dialing %s: %w", node.APIInfo.ListenAPI, err)
+		}
+		defer npCloser()
+
+		nv, err := np.StateNetworkVersion(cctx.Context, types.EmptyTSK)
+		if err != nil {
+			return xerrors.Errorf("getting network version from %s: %w", node.Name, err)
+		}
+
+		mi, err := np.StateMinerInfo(cctx.Context, minerAddr, types.EmptyTSK)
+		if err != nil {
+			return xerrors.Errorf("getting miner info for %s from %s: %w", minerAddr, node.Name, err)
+		}
+
+		if ok, err := miner.IsSupportedSectorSize(mi.SectorSize, nv); err != nil {
+			return xerrors.Errorf("checking sector size support: %w", err)
+		} else if !ok {
+			return xerrors.Errorf("miner %s has sector size %d, which is not supported at network version %d", minerAddr, mi.SectorSize, nv)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err := postApi.NodeAdd(cctx.Context, minerAddr, node); err != nil {
+			return err
+		}
+
+		fmt.Println("add node success.")
+		return nil
+	},
+}
+
+var nodeListCmd = &cli.Command{
+	Name:      "list",
+	Usage:     "list the remote full-node upstreams configured for a miner",
+	ArgsUsage: "<miner address>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		nodes, err := postApi.NodeList(cctx.Context, minerAddr)
+		if err != nil {
+			return err
+		}
+
+		formatJson, err := json.MarshalIndent(nodes, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(formatJson))
+		return nil
+	},
+}
+
+var nodeRemoveCmd = &cli.Command{
+	Name:      "remove",
+	Usage:     "remove a named full-node upstream from a miner",
+	ArgsUsage: "<miner address> <upstream name>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 2 {
+			return xerrors.Errorf("must pass a miner address and an upstream name")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().Get(0))
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err := postApi.NodeRemove(cctx.Context, minerAddr, cctx.Args().Get(1)); err != nil {
+			return err
+		}
+
+		fmt.Println("remove node success.")
+		return nil
+	},
+}
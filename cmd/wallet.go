@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+
+	lcli "github.com/filecoin-project/venus-miner/cli"
+)
+
+var walletCmd = &cli.Command{
+	Name:  "wallet",
+	Usage: "manage the signing backends used to produce blocks",
+	Subcommands: []*cli.Command{
+		walletAddBackendCmd,
+		walletListBackendsCmd,
+		walletRemoveBackendCmd,
+		walletTestBackendCmd,
+	},
+}
+
+var walletAddBackendCmd = &cli.Command{
+	Name:      "add-backend",
+	Usage:     "add a signing backend to a miner, tried after any existing backends",
+	ArgsUsage: "<miner address>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "label for this backend",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "backend type label: jsonrpc, local, hsm (every backend is dialed as a JSON-RPC wallet)",
+			Value: "jsonrpc",
+		},
+		&cli.StringFlag{
+			Name:  "listen-api",
+			Usage: "multiaddr/URL of the remote wallet API",
+		},
+		&cli.StringFlag{
+			Name:  "token",
+			Usage: "auth token for the remote wallet API",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		backend := dtypes.WalletBackend{
+			Name: cctx.String("name"),
+			Type: cctx.String("type"),
+			APIInfo: dtypes.APIInfo{
+				ListenAPI: cctx.String("listen-api"),
+				Token:     cctx.String("token"),
+			},
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err := postApi.WalletAddBackend(cctx.Context, minerAddr, backend); err != nil {
+			return err
+		}
+
+		fmt.Println("add wallet backend success.")
+		return nil
+	},
+}
+
+var walletListBackendsCmd = &cli.Command{
+	Name:      "list-backends",
+	Usage:     "list the signing backends configured for a miner",
+	ArgsUsage: "<miner address>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		backends, err := postApi.WalletListBackends(cctx.Context, minerAddr)
+		if err != nil {
+			return err
+		}
+
+		formatJson, err := json.MarshalIndent(backends, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(formatJson))
+		return nil
+	},
+}
+
+var walletRemoveBackendCmd = &cli.Command{
+	Name:      "remove-backend",
+	Usage:     "remove a named signing backend from a miner",
+	ArgsUsage: "<miner address> <backend name>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 2 {
+			return xerrors.Errorf("must pass a miner address and a backend name")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().Get(0))
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err := postApi.WalletRemoveBackend(cctx.Context, minerAddr, cctx.Args().Get(1)); err != nil {
+			return err
+		}
+
+		fmt.Println("remove wallet backend success.")
+		return nil
+	},
+}
+
+var walletTestBackendCmd = &cli.Command{
+	Name:      "test",
+	Usage:     "check that a miner's signing backends can be reached, in dispatch order",
+	ArgsUsage: "<miner address>",
+	Action: func(cctx *cli.Context) error {
+		if cctx.Args().Len() != 1 {
+			return xerrors.Errorf("must pass exactly one miner address")
+		}
+
+		minerAddr, err := address.NewFromString(cctx.Args().First())
+		if err != nil {
+			return xerrors.Errorf("parsing miner address: %w", err)
+		}
+
+		postApi, closer, err := lcli.GetMinerAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		results, err := postApi.WalletTestBackends(cctx.Context, minerAddr)
+		if err != nil {
+			return err
+		}
+
+		formatJson, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(formatJson))
+		return nil
+	},
+}
@@ -0,0 +1,96 @@
+package miner
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+	"github.com/filecoin-project/venus-miner/node/modules/nodeprovider"
+	"github.com/filecoin-project/venus-miner/node/modules/wallet"
+)
+
+// closerFunc aggregates a group of jsonrpc closers into the single func()
+// nodeProviderFor/dispatcherFor's caller defers.
+type closerFunc func()
+
+func closeAll(closers []jsonrpc.ClientCloser) closerFunc {
+	return func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+}
+
+// nodeProviderFor dials every full-node upstream configured for mi and
+// returns them as a single failover NodeProvider, falling back to the
+// legacy single-node Node field for configs that predate Nodes.
+func nodeProviderFor(ctx context.Context, mi dtypes.MinerInfo) (nodeprovider.NodeProvider, closerFunc, error) {
+	nodes := mi.Nodes
+	if len(nodes) == 0 && mi.Node.ListenAPI != "" {
+		nodes = []dtypes.NodeInfo{{Name: mi.Name, APIInfo: mi.Node}}
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil, xerrors.Errorf("miner %s has no node upstreams configured", mi.Addr)
+	}
+
+	var ups []nodeprovider.Upstream
+	var closers []jsonrpc.ClientCloser
+	for _, n := range nodes {
+		np, closer, err := nodeprovider.DialRemote(ctx, n.Name, n.APIInfo)
+		if err != nil {
+			closeAll(closers)()
+			return nil, nil, xerrors.Errorf("dialing node %s: %w", n.Name, err)
+		}
+
+		ups = append(ups, nodeprovider.Upstream{Name: n.Name, API: np, Closer: func() { closer() }})
+		closers = append(closers, closer)
+	}
+
+	return nodeprovider.NewRoundRobin(ups), closeAll(closers), nil
+}
+
+// dispatcherFor dials every wallet backend configured for mi and returns
+// them as a single ordered Dispatcher, falling back to the legacy single
+// remote Wallet field for configs that predate WalletBackends.
+func dispatcherFor(ctx context.Context, mi dtypes.MinerInfo) (*wallet.Dispatcher, closerFunc, error) {
+	backends := mi.WalletBackends
+	if len(backends) == 0 && mi.Wallet.ListenAPI != "" {
+		backends = []dtypes.WalletBackend{{Name: mi.Name, Type: "jsonrpc", APIInfo: mi.Wallet}}
+	}
+
+	if len(backends) == 0 {
+		return nil, nil, xerrors.Errorf("miner %s has no wallet backends configured", mi.Addr)
+	}
+
+	var wallets []wallet.Wallet
+	var closers []jsonrpc.ClientCloser
+	for _, b := range backends {
+		w, closer, err := wallet.DialRemote(ctx, b.APIInfo)
+		if err != nil {
+			closeAll(closers)()
+			return nil, nil, xerrors.Errorf("dialing wallet backend %s: %w", b.Name, err)
+		}
+
+		wallets = append(wallets, w)
+		closers = append(closers, closer)
+	}
+
+	return wallet.NewDispatcher(wallets...), closeAll(closers), nil
+}
+
+// SignBlock signs addr's block for head using the wallet dispatcher built
+// from that miner's configured backends, so a redundant or migrated
+// signing backend gets used the same way address start/stop production
+// does.
+func (s *Scheduler) SignBlock(ctx context.Context, d *wallet.Dispatcher, addr address.Address, head *types.TipSet) (*crypto.Signature, error) {
+	msg := head.Key().Bytes()
+
+	return d.WalletSign(ctx, addr, msg)
+}
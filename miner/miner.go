@@ -0,0 +1,102 @@
+package miner
+
+import (
+	"context"
+
+	"github.com/prometheus/common/log"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+	"github.com/filecoin-project/venus-miner/node/modules/minermanage"
+)
+
+// Scheduler drives one mining round across every miner a MinerManageAPI
+// knows about. It consults each miner's persisted Policy before doing any
+// work for it, so an operator's pause (address policy set ... pause-until,
+// or a migrated/imported miner's defaulted flags) survives a rolling
+// restart instead of only living in the in-memory start/stop state the
+// address start/stop commands drive.
+type Scheduler struct {
+	manager minermanage.MinerManageAPI
+}
+
+func NewScheduler(manager minermanage.MinerManageAPI) *Scheduler {
+	return &Scheduler{manager: manager}
+}
+
+// ShouldMine reports whether a miner should be considered for any duty at
+// epoch: it must not be paused past epoch, and at least one production duty
+// must be enabled.
+func ShouldMine(policy dtypes.MinerPolicy, epoch abi.ChainEpoch) bool {
+	if epoch < policy.PauseUntil {
+		return false
+	}
+
+	return policy.ConsiderWinningPost || policy.ConsiderWindowPost || policy.ConsiderBlockProduction
+}
+
+// MineOneRound runs one mining round at epoch for every configured miner,
+// skipping any whose persisted Policy disables it for this round.
+func (s *Scheduler) MineOneRound(ctx context.Context, epoch abi.ChainEpoch) error {
+	miners, err := s.manager.List(0, 0, nil)
+	if err != nil {
+		return xerrors.Errorf("listing miners: %w", err)
+	}
+
+	for _, mi := range miners {
+		if !ShouldMine(mi.Policy, epoch) {
+			continue
+		}
+
+		if err := s.mineOne(ctx, mi, epoch); err != nil {
+			log.Warnf("mining round for %s at epoch %d: %s", mi.Addr, epoch, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) mineOne(ctx context.Context, mi dtypes.MinerInfo, epoch abi.ChainEpoch) error {
+	np, npCloser, err := nodeProviderFor(ctx, mi)
+	if err != nil {
+		return xerrors.Errorf("building node provider: %w", err)
+	}
+	defer npCloser()
+
+	head, err := np.ChainHead(ctx)
+	if err != nil {
+		return xerrors.Errorf("getting chain head: %w", err)
+	}
+
+	if !mi.Policy.ConsiderWinningPost && !mi.Policy.ConsiderBlockProduction {
+		return nil
+	}
+
+	baseInfo, err := np.MinerGetBaseInfo(ctx, mi.Addr, epoch, head.Key())
+	if err != nil {
+		return xerrors.Errorf("getting mining base info: %w", err)
+	}
+
+	if baseInfo == nil {
+		// Not elected to mine this round.
+		return nil
+	}
+
+	if !mi.Policy.ConsiderBlockProduction {
+		return nil
+	}
+
+	wd, wdCloser, err := dispatcherFor(ctx, mi)
+	if err != nil {
+		return xerrors.Errorf("building wallet dispatcher: %w", err)
+	}
+	defer wdCloser()
+
+	if _, err := s.SignBlock(ctx, wd, mi.Addr, head); err != nil {
+		return xerrors.Errorf("signing block: %w", err)
+	}
+
+	return nil
+}
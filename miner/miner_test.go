@@ -0,0 +1,33 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+)
+
+func TestShouldMine(t *testing.T) {
+	allOn := dtypes.MinerPolicy{ConsiderWinningPost: true, ConsiderWindowPost: true, ConsiderBlockProduction: true}
+
+	cases := []struct {
+		name   string
+		policy dtypes.MinerPolicy
+		epoch  abi.ChainEpoch
+		want   bool
+	}{
+		{"all duties on, no pause", allOn, 100, true},
+		{"paused until a future epoch", dtypes.MinerPolicy{ConsiderBlockProduction: true, PauseUntil: 200}, 100, false},
+		{"pause has elapsed", dtypes.MinerPolicy{ConsiderBlockProduction: true, PauseUntil: 200}, 300, true},
+		{"every duty disabled", dtypes.MinerPolicy{}, 100, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ShouldMine(c.policy, c.epoch); got != c.want {
+				t.Fatalf("ShouldMine(%+v, %d) = %v, want %v", c.policy, c.epoch, got, c.want)
+			}
+		})
+	}
+}
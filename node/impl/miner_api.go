@@ -0,0 +1,163 @@
+package impl
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus-miner/api"
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+	"github.com/filecoin-project/venus-miner/node/modules/minermanage"
+	"github.com/filecoin-project/venus-miner/node/modules/wallet"
+)
+
+// MinerAPI implements api.MinerAPI on top of a MinerManageAPI, translating
+// each RPC call into the storage operations the manager already serializes
+// per miner address.
+type MinerAPI struct {
+	Manager minermanage.MinerManageAPI
+}
+
+func NewMinerAPI(manager minermanage.MinerManageAPI) *MinerAPI {
+	return &MinerAPI{Manager: manager}
+}
+
+func (a *MinerAPI) ImportAddress(ctx context.Context, miners []dtypes.MinerInfo) ([]dtypes.ImportResult, error) {
+	return a.Manager.PutBatch(miners), nil
+}
+
+func (a *MinerAPI) PolicyGet(ctx context.Context, addr address.Address) (dtypes.MinerPolicy, error) {
+	return a.Manager.Policy(addr)
+}
+
+func (a *MinerAPI) PolicySet(ctx context.Context, addr address.Address, flag, value string) error {
+	return a.Manager.SetPolicy(addr, flag, value)
+}
+
+func (a *MinerAPI) WalletAddBackend(ctx context.Context, addr address.Address, backend dtypes.WalletBackend) error {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return xerrors.Errorf("miner %s not found", addr)
+	}
+
+	for _, b := range mi.WalletBackends {
+		if b.Name == backend.Name {
+			return xerrors.Errorf("wallet backend %q already exists", backend.Name)
+		}
+	}
+
+	mi.WalletBackends = append(mi.WalletBackends, backend)
+	return a.Manager.Set(*mi)
+}
+
+func (a *MinerAPI) WalletListBackends(ctx context.Context, addr address.Address) ([]dtypes.WalletBackend, error) {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return nil, xerrors.Errorf("miner %s not found", addr)
+	}
+
+	return mi.WalletBackends, nil
+}
+
+func (a *MinerAPI) WalletRemoveBackend(ctx context.Context, addr address.Address, name string) error {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return xerrors.Errorf("miner %s not found", addr)
+	}
+
+	out := mi.WalletBackends[:0]
+	found := false
+	for _, b := range mi.WalletBackends {
+		if b.Name == name {
+			found = true
+			continue
+		}
+		out = append(out, b)
+	}
+	if !found {
+		return xerrors.Errorf("wallet backend %q not found", name)
+	}
+	mi.WalletBackends = out
+
+	return a.Manager.Set(*mi)
+}
+
+func (a *MinerAPI) WalletTestBackends(ctx context.Context, addr address.Address) ([]api.WalletTestResult, error) {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return nil, xerrors.Errorf("miner %s not found", addr)
+	}
+
+	results := make([]api.WalletTestResult, 0, len(mi.WalletBackends))
+	for _, b := range mi.WalletBackends {
+		w, closer, err := wallet.DialRemote(ctx, b.APIInfo)
+		if err != nil {
+			results = append(results, api.WalletTestResult{Name: b.Name, Err: err.Error()})
+			continue
+		}
+
+		_, err = w.WalletList(ctx)
+		closer()
+
+		if err != nil {
+			results = append(results, api.WalletTestResult{Name: b.Name, Err: err.Error()})
+			continue
+		}
+
+		results = append(results, api.WalletTestResult{Name: b.Name, OK: true})
+	}
+
+	return results, nil
+}
+
+func (a *MinerAPI) NodeAdd(ctx context.Context, addr address.Address, node dtypes.NodeInfo) error {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return xerrors.Errorf("miner %s not found", addr)
+	}
+
+	for _, n := range mi.Nodes {
+		if n.Name == node.Name {
+			return xerrors.Errorf("node %q already exists", node.Name)
+		}
+	}
+
+	mi.Nodes = append(mi.Nodes, node)
+	return a.Manager.Set(*mi)
+}
+
+func (a *MinerAPI) NodeList(ctx context.Context, addr address.Address) ([]dtypes.NodeInfo, error) {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return nil, xerrors.Errorf("miner %s not found", addr)
+	}
+
+	return mi.Nodes, nil
+}
+
+func (a *MinerAPI) NodeRemove(ctx context.Context, addr address.Address, name string) error {
+	mi := a.Manager.Get(addr)
+	if mi == nil {
+		return xerrors.Errorf("miner %s not found", addr)
+	}
+
+	out := mi.Nodes[:0]
+	found := false
+	for _, n := range mi.Nodes {
+		if n.Name == name {
+			found = true
+			continue
+		}
+		out = append(out, n)
+	}
+	if !found {
+		return xerrors.Errorf("node %q not found", name)
+	}
+	mi.Nodes = out
+
+	return a.Manager.Set(*mi)
+}
+
+var _ api.MinerAPI = (*MinerAPI)(nil)
@@ -0,0 +1,153 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+	"github.com/filecoin-project/venus-miner/node/modules/minermanage"
+)
+
+func newTestAPI(t *testing.T) (*MinerAPI, address.Address) {
+	t.Helper()
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	mgr, err := minermanage.NewMinerManger(ds)
+	if err != nil {
+		t.Fatalf("NewMinerManger: %s", err)
+	}
+
+	addr, err := address.NewIDAddress(2000)
+	if err != nil {
+		t.Fatalf("building test address: %s", err)
+	}
+
+	if err := mgr.Put(dtypes.MinerInfo{Addr: addr}); err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	return NewMinerAPI(mgr), addr
+}
+
+func TestPolicyGetSetRoundTrips(t *testing.T) {
+	a, addr := newTestAPI(t)
+	ctx := context.Background()
+
+	if err := a.PolicySet(ctx, addr, "consider-window-post", "false"); err != nil {
+		t.Fatalf("PolicySet: %s", err)
+	}
+
+	policy, err := a.PolicyGet(ctx, addr)
+	if err != nil {
+		t.Fatalf("PolicyGet: %s", err)
+	}
+
+	if policy.ConsiderWindowPost {
+		t.Fatalf("expected ConsiderWindowPost to be false after PolicySet, got %+v", policy)
+	}
+}
+
+func TestWalletAddListRemoveBackend(t *testing.T) {
+	a, addr := newTestAPI(t)
+	ctx := context.Background()
+
+	backend := dtypes.WalletBackend{Name: "primary", Type: "jsonrpc"}
+	if err := a.WalletAddBackend(ctx, addr, backend); err != nil {
+		t.Fatalf("WalletAddBackend: %s", err)
+	}
+
+	if err := a.WalletAddBackend(ctx, addr, backend); err == nil {
+		t.Fatalf("expected adding a duplicate backend name to fail")
+	}
+
+	backends, err := a.WalletListBackends(ctx, addr)
+	if err != nil {
+		t.Fatalf("WalletListBackends: %s", err)
+	}
+	if len(backends) != 1 || backends[0].Name != "primary" {
+		t.Fatalf("expected exactly the added backend, got %+v", backends)
+	}
+
+	if err := a.WalletRemoveBackend(ctx, addr, "primary"); err != nil {
+		t.Fatalf("WalletRemoveBackend: %s", err)
+	}
+
+	backends, err = a.WalletListBackends(ctx, addr)
+	if err != nil {
+		t.Fatalf("WalletListBackends after remove: %s", err)
+	}
+	if len(backends) != 0 {
+		t.Fatalf("expected no backends after removal, got %+v", backends)
+	}
+
+	if err := a.WalletRemoveBackend(ctx, addr, "primary"); err == nil {
+		t.Fatalf("expected removing an already-removed backend to fail")
+	}
+}
+
+func TestNodeAddListRemove(t *testing.T) {
+	a, addr := newTestAPI(t)
+	ctx := context.Background()
+
+	node := dtypes.NodeInfo{Name: "us-east"}
+	if err := a.NodeAdd(ctx, addr, node); err != nil {
+		t.Fatalf("NodeAdd: %s", err)
+	}
+
+	if err := a.NodeAdd(ctx, addr, node); err == nil {
+		t.Fatalf("expected adding a duplicate node name to fail")
+	}
+
+	nodes, err := a.NodeList(ctx, addr)
+	if err != nil {
+		t.Fatalf("NodeList: %s", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "us-east" {
+		t.Fatalf("expected exactly the added node, got %+v", nodes)
+	}
+
+	if err := a.NodeRemove(ctx, addr, "us-east"); err != nil {
+		t.Fatalf("NodeRemove: %s", err)
+	}
+
+	nodes, err = a.NodeList(ctx, addr)
+	if err != nil {
+		t.Fatalf("NodeList after remove: %s", err)
+	}
+	if len(nodes) != 0 {
+		t.Fatalf("expected no nodes after removal, got %+v", nodes)
+	}
+}
+
+func TestImportAddressReportsPerEntryResult(t *testing.T) {
+	a, existing := newTestAPI(t)
+	ctx := context.Background()
+
+	fresh, err := address.NewIDAddress(2001)
+	if err != nil {
+		t.Fatalf("building test address: %s", err)
+	}
+
+	results, err := a.ImportAddress(ctx, []dtypes.MinerInfo{
+		{Addr: existing},
+		{Addr: fresh},
+	})
+	if err != nil {
+		t.Fatalf("ImportAddress: %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected one result per entry, got %d", len(results))
+	}
+	if results[0].Err == "" {
+		t.Fatalf("expected importing an already-existing miner to fail, got %+v", results[0])
+	}
+	if results[1].Err != "" {
+		t.Fatalf("expected importing a new miner to succeed, got %+v", results[1])
+	}
+}
@@ -0,0 +1,10 @@
+package dtypes
+
+import (
+	"github.com/ipfs/go-datastore"
+)
+
+// MetadataDS is the datastore venus-miner uses to persist its own
+// configuration (miner list, default address, ...), as opposed to chain
+// data.
+type MetadataDS datastore.Batching
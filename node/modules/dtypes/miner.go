@@ -0,0 +1,92 @@
+package dtypes
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// MinerInfo describes a miner bound to this venus-miner instance: the
+// address it seals and produces blocks for, and the backend endpoints it
+// talks to in order to do so.
+type MinerInfo struct {
+	Addr address.Address
+
+	Id   string
+	Name string
+
+	Sealer APIInfo
+
+	// Wallet is kept for configs that only ever set a single remote wallet.
+	// New configuration should use WalletBackends instead.
+	Wallet APIInfo
+
+	// WalletBackends lists the signing backends configured for this miner,
+	// tried in order until one can produce the requested signature.
+	WalletBackends []WalletBackend
+
+	// Node is kept for configs that only ever talk to a single co-located
+	// full node. New configuration should use Nodes instead.
+	Node APIInfo
+
+	// Nodes lists the remote venus/lotus full-node upstreams this miner
+	// talks to in lite mode, dispatched round-robin with failover.
+	Nodes []NodeInfo
+
+	// Policy is this miner's persisted production flags. It is consulted by
+	// the mining loop on every round, so an operator's pause survives a
+	// rolling restart instead of only living in the in-memory start/stop
+	// state.
+	Policy MinerPolicy
+}
+
+// MinerPolicy is a miner's persisted production policy: which duties it
+// should be considered for, and whether it is paused until some future
+// epoch regardless of those duties.
+type MinerPolicy struct {
+	ConsiderWinningPost     bool
+	ConsiderWindowPost      bool
+	ConsiderBlockProduction bool
+
+	// PauseUntil suppresses all production for this miner until this
+	// epoch, even if the flags above are enabled.
+	PauseUntil abi.ChainEpoch
+}
+
+// APIInfo is the endpoint and auth token of a remote JSON-RPC API, used for
+// the sealer, wallet and full-node connections.
+type APIInfo struct {
+	ListenAPI string
+	Token     string
+}
+
+// ImportResult reports the outcome of importing a single MinerInfo during a
+// bulk import, so one malformed entry doesn't abort the rest of the batch.
+type ImportResult struct {
+	Addr address.Address
+	Err  string
+}
+
+// WalletBackend is one signing backend a miner can dispatch to. Every
+// backend is dialed as a remote venus-wallet/lotus-wallet JSON-RPC endpoint
+// at its APIInfo; there is no local keystore or HSM/KMS implementation.
+type WalletBackend struct {
+	// Name identifies this backend within a miner's backend list, e.g. for
+	// `wallet remove-backend` and `wallet test` output.
+	Name string
+
+	// Type labels the backend: "jsonrpc", "local", "hsm". It is stored with
+	// the backend but does not change how the backend is dialed.
+	Type string
+
+	APIInfo
+}
+
+// NodeInfo is one remote full-node upstream a miner can dispatch chain
+// calls to in lite mode.
+type NodeInfo struct {
+	// Name identifies this upstream within a miner's node list, e.g. for
+	// `node remove` and the active-upstream field in `address state`.
+	Name string
+
+	APIInfo
+}
@@ -2,181 +2,382 @@ package minermanage
 
 import (
 	"encoding/json"
+	"strconv"
 	"sync"
 
 	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
 	"github.com/prometheus/common/log"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
 )
 
-const actorKey = "miner-actors"
+// minerKeyPrefix namespaces one datastore key per miner, so Has/Get/Set
+// touch a single record instead of rewriting every miner on every write.
+const minerKeyPrefix = "miner-actors/"
+
+// legacyActorKey is the single JSON-blob key older versions of venus-miner
+// stored the whole miner list under. NewMinerManger imports it into the
+// per-miner layout on first start and then deletes it.
+const legacyActorKey = "miner-actors"
+
 const defaultKey = "default-actor"
 
 var ErrNoDefault = xerrors.Errorf("not set default key")
 
+// MinerInfoFilter reports whether a miner should be included in a List
+// result.
+type MinerInfoFilter func(dtypes.MinerInfo) bool
+
 type MinerManageAPI interface {
 	Put(addr dtypes.MinerInfo) error
 	Set(addr dtypes.MinerInfo) error
 	Has(checkAddr address.Address) bool
 	Get(checkAddr address.Address) *dtypes.MinerInfo
-	List() ([]dtypes.MinerInfo, error)
+	List(skip, limit int64, filter MinerInfoFilter) ([]dtypes.MinerInfo, error)
 	Remove(addrs []address.Address) error
+	PutBatch(miners []dtypes.MinerInfo) []dtypes.ImportResult
+	Policy(addr address.Address) (dtypes.MinerPolicy, error)
+	SetPolicy(addr address.Address, flag, value string) error
 	Count() int
 }
 
+// MinerManager stores one datastore record per miner, keyed by address, so
+// it scales to the hundreds/thousands of miners a shared venus-miner
+// cluster may carry without holding the whole list in memory under one
+// lock.
 type MinerManager struct {
-	miners []dtypes.MinerInfo
-
 	da dtypes.MetadataDS
-	lk sync.Mutex
+
+	// keyLocks serializes the read-modify-write done by Set/SetPolicy on a
+	// single miner, so two concurrent updates to the same address can't
+	// race and silently drop one of them. Keyed by address string, one
+	// *sync.Mutex per miner ever touched.
+	keyLocks sync.Map
+}
+
+func minerKey(addr address.Address) datastore.Key {
+	return datastore.NewKey(minerKeyPrefix + addr.String())
+}
+
+// lockAddr locks the per-miner mutex for addr and returns a function to
+// unlock it.
+func (m *MinerManager) lockAddr(addr address.Address) func() {
+	v, _ := m.keyLocks.LoadOrStore(addr.String(), &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+
+	return mu.Unlock
 }
 
 func NewMinerManger(ds dtypes.MetadataDS) (*MinerManager, error) {
-	addrBytes, err := ds.Get(datastore.NewKey(actorKey))
-	if err != nil && err != datastore.ErrNotFound {
+	m := &MinerManager{da: ds}
+
+	if err := m.migrateLegacy(); err != nil {
 		return nil, err
 	}
 
+	return m, nil
+}
+
+// migrateLegacy reads the legacy single-blob miner list, if any, and
+// imports each entry into the per-miner layout. It is idempotent: once the
+// legacy key is gone this is a no-op on every subsequent start.
+func (m *MinerManager) migrateLegacy() error {
+	legacyBytes, err := m.da.Get(datastore.NewKey(legacyActorKey))
+	if err != nil {
+		if err == datastore.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
 	var miners []dtypes.MinerInfo
+	if err := json.Unmarshal(legacyBytes, &miners); err != nil {
+		return err
+	}
 
-	if err != datastore.ErrNotFound {
-		err = json.Unmarshal(addrBytes, &miners)
-		if err != nil {
-			return nil, err
+	for _, miner := range miners {
+		if m.Has(miner.Addr) {
+			continue
+		}
+
+		miner.Policy = defaultPolicy(miner.Policy)
+
+		if err := m.put(miner); err != nil {
+			return err
 		}
 	}
 
-	return &MinerManager{da: ds, miners: miners}, nil
+	return m.da.Delete(datastore.NewKey(legacyActorKey))
 }
 
-func (m *MinerManager) Put(miner dtypes.MinerInfo) error {
-	m.lk.Lock()
-	defer m.lk.Unlock()
-
-	if m.Has(miner.Addr) {
-		log.Warnf("addr %s has exit", miner.Addr)
-		return nil
+// defaultPolicy fills in the all-considered-on policy for a MinerInfo that
+// predates the Policy field (the legacy single-blob store, or an
+// `address import` file written against the old schema), so importing one
+// doesn't silently disable production for a miner that was never paused.
+func defaultPolicy(p dtypes.MinerPolicy) dtypes.MinerPolicy {
+	if p == (dtypes.MinerPolicy{}) {
+		return dtypes.MinerPolicy{
+			ConsiderWinningPost:     true,
+			ConsiderWindowPost:      true,
+			ConsiderBlockProduction: true,
+		}
 	}
 
-	newMiner := append(m.miners, miner)
-	addrBytes, err := json.Marshal(newMiner)
+	return p
+}
+
+func (m *MinerManager) put(miner dtypes.MinerInfo) error {
+	addrBytes, err := json.Marshal(miner)
 	if err != nil {
 		return err
 	}
-	err = m.da.Put(datastore.NewKey(actorKey), addrBytes)
-	if err != nil {
-		return err
+
+	return m.da.Put(minerKey(miner.Addr), addrBytes)
+}
+
+func (m *MinerManager) Put(miner dtypes.MinerInfo) error {
+	if m.Has(miner.Addr) {
+		log.Warnf("addr %s has exit", miner.Addr)
+		return nil
 	}
 
-	m.miners = newMiner
-	return nil
+	return m.put(miner)
 }
 
 func (m *MinerManager) Set(miner dtypes.MinerInfo) error {
-	m.lk.Lock()
-	defer m.lk.Unlock()
+	unlock := m.lockAddr(miner.Addr)
+	defer unlock()
 
-	for k, addr := range m.miners {
-		if addr.Addr.String() == miner.Addr.String() {
-			if miner.Sealer.ListenAPI != "" && miner.Sealer.ListenAPI != m.miners[k].Sealer.ListenAPI {
-				m.miners[k].Sealer.ListenAPI = miner.Sealer.ListenAPI
-			}
+	cur := m.Get(miner.Addr)
+	if cur == nil {
+		return nil
+	}
 
-			if miner.Sealer.Token != "" && miner.Sealer.Token != m.miners[k].Sealer.Token {
-				m.miners[k].Sealer.Token = miner.Sealer.Token
-			}
+	if miner.Sealer.ListenAPI != "" && miner.Sealer.ListenAPI != cur.Sealer.ListenAPI {
+		cur.Sealer.ListenAPI = miner.Sealer.ListenAPI
+	}
 
-			if miner.Wallet.ListenAPI != "" && miner.Wallet.ListenAPI != m.miners[k].Wallet.ListenAPI {
-				m.miners[k].Wallet.ListenAPI = miner.Wallet.ListenAPI
-			}
+	if miner.Sealer.Token != "" && miner.Sealer.Token != cur.Sealer.Token {
+		cur.Sealer.Token = miner.Sealer.Token
+	}
 
-			if miner.Wallet.Token != "" && miner.Wallet.Token != m.miners[k].Wallet.Token {
-				m.miners[k].Wallet.Token = miner.Wallet.Token
-			}
+	if miner.Wallet.ListenAPI != "" && miner.Wallet.ListenAPI != cur.Wallet.ListenAPI {
+		cur.Wallet.ListenAPI = miner.Wallet.ListenAPI
+	}
 
-			addrBytes, err := json.Marshal(m.miners)
-			if err != nil {
-				return err
-			}
+	if miner.Wallet.Token != "" && miner.Wallet.Token != cur.Wallet.Token {
+		cur.Wallet.Token = miner.Wallet.Token
+	}
 
-			err = m.da.Put(datastore.NewKey(actorKey), addrBytes)
-			if err != nil {
-				return err
-			}
+	if miner.WalletBackends != nil {
+		cur.WalletBackends = miner.WalletBackends
+	}
 
-			break
-		}
+	if miner.Node.ListenAPI != "" && miner.Node.ListenAPI != cur.Node.ListenAPI {
+		cur.Node.ListenAPI = miner.Node.ListenAPI
 	}
 
-	return nil
+	if miner.Node.Token != "" && miner.Node.Token != cur.Node.Token {
+		cur.Node.Token = miner.Node.Token
+	}
+
+	if miner.Nodes != nil {
+		cur.Nodes = miner.Nodes
+	}
+
+	return m.put(*cur)
 }
 
 func (m *MinerManager) Has(addr address.Address) bool {
-	for _, miner := range m.miners {
-		if miner.Addr.String() == addr.String() {
-			return true
-		}
+	has, err := m.da.Has(minerKey(addr))
+	if err != nil {
+		log.Warnf("check miner %s existence: %s", addr, err)
+		return false
 	}
 
-	return false
+	return has
 }
 
 func (m *MinerManager) Get(addr address.Address) *dtypes.MinerInfo {
-	m.lk.Lock()
-	defer m.lk.Unlock()
+	addrBytes, err := m.da.Get(minerKey(addr))
+	if err != nil {
+		return nil
+	}
 
-	for k := range m.miners {
-		if m.miners[k].Addr.String() == addr.String() {
-			return &m.miners[k]
-		}
+	var miner dtypes.MinerInfo
+	if err := json.Unmarshal(addrBytes, &miner); err != nil {
+		log.Warnf("unmarshal miner %s: %s", addr, err)
+		return nil
 	}
 
-	return nil
+	return &miner
 }
 
-func (m *MinerManager) List() ([]dtypes.MinerInfo, error) {
-	m.lk.Lock()
-	defer m.lk.Unlock()
+// List returns up to limit miners matching filter, ordered by address and
+// skipping the first skip matches, so large deployments can page through
+// their miner set instead of loading it all at once. A nil filter matches
+// everything; a non-positive limit returns all remaining matches.
+func (m *MinerManager) List(skip, limit int64, filter MinerInfoFilter) ([]dtypes.MinerInfo, error) {
+	results, err := m.da.Query(dsq.Query{
+		Prefix: "/" + minerKeyPrefix,
+		Orders: []dsq.Order{dsq.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() // nolint:errcheck
+
+	var out []dtypes.MinerInfo
+	var matched int64
+	for entry := range results.Next() {
+		if entry.Error != nil {
+			return nil, entry.Error
+		}
+
+		var miner dtypes.MinerInfo
+		if err := json.Unmarshal(entry.Value, &miner); err != nil {
+			return nil, err
+		}
+
+		if filter != nil && !filter(miner) {
+			continue
+		}
+
+		if matched < skip {
+			matched++
+			continue
+		}
+		matched++
+
+		out = append(out, miner)
+		if limit > 0 && int64(len(out)) >= limit {
+			break
+		}
+	}
 
-	return m.miners, nil
+	return out, nil
 }
 
-func findAddress(addr address.Address, addrs []address.Address) bool {
-	for _, a := range addrs {
-		if a.String() != addr.String() {
-			return true
+// PutBatch imports miners in one datastore batch where the underlying
+// store supports it, and reports a per-entry result so one malformed or
+// duplicate record doesn't abort the rest of the batch.
+func (m *MinerManager) PutBatch(miners []dtypes.MinerInfo) []dtypes.ImportResult {
+	results := make([]dtypes.ImportResult, 0, len(miners))
+
+	batch, err := m.da.Batch()
+	if err != nil {
+		log.Warnf("starting batch import, falling back to per-entry puts: %s", err)
+		batch = nil
+	}
+
+	for _, miner := range miners {
+		if m.Has(miner.Addr) {
+			results = append(results, dtypes.ImportResult{Addr: miner.Addr, Err: "already exists"})
+			continue
 		}
+
+		miner.Policy = defaultPolicy(miner.Policy)
+
+		addrBytes, err := json.Marshal(miner)
+		if err != nil {
+			results = append(results, dtypes.ImportResult{Addr: miner.Addr, Err: err.Error()})
+			continue
+		}
+
+		if batch != nil {
+			err = batch.Put(minerKey(miner.Addr), addrBytes)
+		} else {
+			err = m.da.Put(minerKey(miner.Addr), addrBytes)
+		}
+
+		if err != nil {
+			results = append(results, dtypes.ImportResult{Addr: miner.Addr, Err: err.Error()})
+			continue
+		}
+
+		results = append(results, dtypes.ImportResult{Addr: miner.Addr})
 	}
 
-	return false
+	if batch != nil {
+		if err := batch.Commit(); err != nil {
+			for i := range results {
+				if results[i].Err == "" {
+					results[i].Err = err.Error()
+				}
+			}
+		}
+	}
+
+	return results
 }
 
 func (m *MinerManager) Remove(addrs []address.Address) error {
-	m.lk.Lock()
-	defer m.lk.Unlock()
-
-	var newMiners []dtypes.MinerInfo
-	for _, miner := range m.miners {
-		if !findAddress(miner.Addr, addrs) {
-			newMiners = append(newMiners, miner)
+	for _, addr := range addrs {
+		if err := m.da.Delete(minerKey(addr)); err != nil {
+			return err
 		}
 	}
 
-	addrBytes, err := json.Marshal(newMiners)
-	if err != nil {
-		return err
+	return nil
+}
+
+// Policy returns a miner's persisted production policy.
+func (m *MinerManager) Policy(addr address.Address) (dtypes.MinerPolicy, error) {
+	cur := m.Get(addr)
+	if cur == nil {
+		return dtypes.MinerPolicy{}, xerrors.Errorf("miner %s not found", addr)
 	}
-	err = m.da.Put(datastore.NewKey(actorKey), addrBytes)
-	if err != nil {
-		return err
+
+	return cur.Policy, nil
+}
+
+// SetPolicy updates a single named policy flag for a miner and persists it
+// immediately, so a paused miner stays paused across a rolling restart
+// instead of only living in the mining loop's in-memory start/stop state.
+func (m *MinerManager) SetPolicy(addr address.Address, flag, value string) error {
+	unlock := m.lockAddr(addr)
+	defer unlock()
+
+	cur := m.Get(addr)
+	if cur == nil {
+		return xerrors.Errorf("miner %s not found", addr)
 	}
 
-	m.miners = newMiners
+	switch flag {
+	case "consider-winning-post":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return xerrors.Errorf("parsing %s as bool: %w", flag, err)
+		}
+		cur.Policy.ConsiderWinningPost = b
+	case "consider-window-post":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return xerrors.Errorf("parsing %s as bool: %w", flag, err)
+		}
+		cur.Policy.ConsiderWindowPost = b
+	case "consider-block-production":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return xerrors.Errorf("parsing %s as bool: %w", flag, err)
+		}
+		cur.Policy.ConsiderBlockProduction = b
+	case "pause-until":
+		epoch, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return xerrors.Errorf("parsing %s as epoch: %w", flag, err)
+		}
+		cur.Policy.PauseUntil = abi.ChainEpoch(epoch)
+	default:
+		return xerrors.Errorf("unknown policy flag %q", flag)
+	}
 
-	return nil
+	return m.put(*cur)
 }
 
 func (m *MinerManager) SetDefault(addr address.Address) error {
@@ -184,29 +385,44 @@ func (m *MinerManager) SetDefault(addr address.Address) error {
 }
 
 func (m *MinerManager) Default() (address.Address, error) {
-	bytes, err := m.da.Get(datastore.NewKey(defaultKey))
+	addrBytes, err := m.da.Get(datastore.NewKey(defaultKey))
 	if err != nil {
-		// set the address with index 0 as the default address
-		if len(m.miners) == 0 {
+		miners, lerr := m.List(0, 1, nil)
+		if lerr != nil {
+			return address.Undef, lerr
+		}
+
+		if len(miners) == 0 {
 			return address.Undef, ErrNoDefault
 		}
 
-		err = m.SetDefault(m.miners[0].Addr)
-		if err != nil {
+		if err := m.SetDefault(miners[0].Addr); err != nil {
 			return address.Undef, err
 		}
 
-		return m.miners[0].Addr, nil
+		return miners[0].Addr, nil
 	}
 
-	return address.NewFromBytes(bytes)
+	return address.NewFromBytes(addrBytes)
 }
 
 func (m *MinerManager) Count() int {
-	m.lk.Lock()
-	defer m.lk.Unlock()
+	results, err := m.da.Query(dsq.Query{
+		Prefix:   "/" + minerKeyPrefix,
+		KeysOnly: true,
+	})
+	if err != nil {
+		log.Warnf("count miners: %s", err)
+		return 0
+	}
+	defer results.Close() // nolint:errcheck
+
+	n := 0
+	for range results.Next() {
+		n++
+	}
 
-	return len(m.miners)
+	return n
 }
 
 var _ MinerManageAPI = &MinerManager{}
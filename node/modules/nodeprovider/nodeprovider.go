@@ -0,0 +1,28 @@
+package nodeprovider
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// NodeProvider is the subset of the venus/lotus full-node API a lite-mode
+// miner needs in order to mine without any embedded chain services of its
+// own: chain head and base-info lookups, mempool selection, and block
+// submission.
+type NodeProvider interface {
+	ChainHead(ctx context.Context) (*types.TipSet, error)
+	MinerGetBaseInfo(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error)
+	StateMinerInfo(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)
+	StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error)
+	MpoolSelect(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error)
+	SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error
+
+	// Name reports which configured upstream currently serves calls, for
+	// surfacing in `venus-miner address state` output.
+	Name() string
+}
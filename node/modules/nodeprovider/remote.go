@@ -0,0 +1,82 @@
+package nodeprovider
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+)
+
+// remoteAPI is the subset of the venus/lotus full-node JSON-RPC API a single
+// upstream needs.
+type remoteAPI struct {
+	ChainHead           func(ctx context.Context) (*types.TipSet, error)
+	MinerGetBaseInfo    func(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error)
+	StateMinerInfo      func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)
+	StateNetworkVersion func(ctx context.Context, tsk types.TipSetKey) (network.Version, error)
+	MpoolSelect         func(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error)
+	SyncSubmitBlock     func(ctx context.Context, blk *types.BlockMsg) error
+}
+
+type remote struct {
+	name string
+	api  remoteAPI
+}
+
+// DialRemote connects to a single venus/lotus full-node JSON-RPC endpoint
+// and returns it wrapped as a NodeProvider, plus a closer the caller must
+// invoke once the connection is no longer in use. It is used both to
+// validate a node before it is added to a miner's upstream list, and to
+// build the per-miner RoundRobin that serves the mining loop.
+func DialRemote(ctx context.Context, name string, info dtypes.APIInfo) (NodeProvider, jsonrpc.ClientCloser, error) {
+	var rapi remoteAPI
+
+	headers := map[string][]string{}
+	if info.Token != "" {
+		headers["Authorization"] = []string{"Bearer " + info.Token}
+	}
+
+	closer, err := jsonrpc.NewMergeClient(ctx, info.ListenAPI, "Filecoin", []interface{}{&rapi}, headers)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("dialing remote node %s: %w", info.ListenAPI, err)
+	}
+
+	return &remote{name: name, api: rapi}, closer, nil
+}
+
+func (r *remote) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	return r.api.ChainHead(ctx)
+}
+
+func (r *remote) MinerGetBaseInfo(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) {
+	return r.api.MinerGetBaseInfo(ctx, maddr, epoch, tsk)
+}
+
+func (r *remote) StateMinerInfo(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error) {
+	return r.api.StateMinerInfo(ctx, maddr, tsk)
+}
+
+func (r *remote) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
+	return r.api.StateNetworkVersion(ctx, tsk)
+}
+
+func (r *remote) MpoolSelect(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error) {
+	return r.api.MpoolSelect(ctx, tsk, ticketQuality)
+}
+
+func (r *remote) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error {
+	return r.api.SyncSubmitBlock(ctx, blk)
+}
+
+func (r *remote) Name() string {
+	return r.name
+}
+
+var _ NodeProvider = (*remote)(nil)
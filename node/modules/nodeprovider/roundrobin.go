@@ -0,0 +1,217 @@
+package nodeprovider
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// Upstream is one configured remote node a RoundRobin can route calls to.
+type Upstream struct {
+	Name   string
+	API    NodeProvider
+	Closer func()
+}
+
+type trackedUpstream struct {
+	Upstream
+	healthy int32 // atomic bool, 1 = healthy
+}
+
+// RoundRobin dispatches across a list of upstream nodes in round-robin
+// order, trying any HealthCheck has marked unhealthy only after the healthy
+// ones and failing over to the next upstream on a call error.
+type RoundRobin struct {
+	ups []*trackedUpstream
+	idx uint64
+}
+
+func NewRoundRobin(ups []Upstream) *RoundRobin {
+	tracked := make([]*trackedUpstream, len(ups))
+	for i, u := range ups {
+		tracked[i] = &trackedUpstream{Upstream: u, healthy: 1}
+	}
+
+	return &RoundRobin{ups: tracked}
+}
+
+// HealthCheck pings every upstream's ChainHead and marks it healthy or
+// unhealthy accordingly, so later dispatches try it only after every
+// healthy upstream until it recovers.
+func (r *RoundRobin) HealthCheck(ctx context.Context) {
+	for _, u := range r.ups {
+		hctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := u.API.ChainHead(hctx)
+		cancel()
+
+		healthy := int32(0)
+		if err == nil {
+			healthy = 1
+		}
+		atomic.StoreInt32(&u.healthy, healthy)
+	}
+}
+
+// orderFrom returns the upstreams starting at index start in round-robin
+// order, with any upstream HealthCheck has marked unhealthy moved to the
+// back, so a failed call fails over to the healthy upstreams before it
+// tries one known to be down.
+func (r *RoundRobin) orderFrom(start uint64) []*trackedUpstream {
+	n := len(r.ups)
+	rotated := make([]*trackedUpstream, 0, n)
+	for i := 0; i < n; i++ {
+		rotated = append(rotated, r.ups[(int(start)+i)%n])
+	}
+
+	ordered := make([]*trackedUpstream, 0, n)
+	var unhealthy []*trackedUpstream
+	for _, u := range rotated {
+		if atomic.LoadInt32(&u.healthy) == 1 {
+			ordered = append(ordered, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	return append(ordered, unhealthy...)
+}
+
+// next advances the round-robin cursor and returns upstreams in that order,
+// healthy ones first and unhealthy ones last.
+func (r *RoundRobin) next() ([]*trackedUpstream, error) {
+	if len(r.ups) == 0 {
+		return nil, xerrors.Errorf("no upstream nodes configured")
+	}
+
+	n := atomic.AddUint64(&r.idx, 1)
+	return r.orderFrom(n), nil
+}
+
+func (r *RoundRobin) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	ups, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		ts, err := u.API.ChainHead(ctx)
+		if err == nil {
+			return ts, nil
+		}
+		lastErr = err
+	}
+
+	return nil, xerrors.Errorf("all upstream nodes failed ChainHead: %w", lastErr)
+}
+
+func (r *RoundRobin) MinerGetBaseInfo(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) {
+	ups, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		bi, err := u.API.MinerGetBaseInfo(ctx, maddr, epoch, tsk)
+		if err == nil {
+			return bi, nil
+		}
+		lastErr = err
+	}
+
+	return nil, xerrors.Errorf("all upstream nodes failed MinerGetBaseInfo: %w", lastErr)
+}
+
+func (r *RoundRobin) StateMinerInfo(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error) {
+	ups, err := r.next()
+	if err != nil {
+		return types.MinerInfo{}, err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		mi, err := u.API.StateMinerInfo(ctx, maddr, tsk)
+		if err == nil {
+			return mi, nil
+		}
+		lastErr = err
+	}
+
+	return types.MinerInfo{}, xerrors.Errorf("all upstream nodes failed StateMinerInfo: %w", lastErr)
+}
+
+func (r *RoundRobin) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
+	ups, err := r.next()
+	if err != nil {
+		return 0, err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		nv, err := u.API.StateNetworkVersion(ctx, tsk)
+		if err == nil {
+			return nv, nil
+		}
+		lastErr = err
+	}
+
+	return 0, xerrors.Errorf("all upstream nodes failed StateNetworkVersion: %w", lastErr)
+}
+
+func (r *RoundRobin) MpoolSelect(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error) {
+	ups, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		msgs, err := u.API.MpoolSelect(ctx, tsk, ticketQuality)
+		if err == nil {
+			return msgs, nil
+		}
+		lastErr = err
+	}
+
+	return nil, xerrors.Errorf("all upstream nodes failed MpoolSelect: %w", lastErr)
+}
+
+func (r *RoundRobin) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error {
+	ups, err := r.next()
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, u := range ups {
+		if err := u.API.SyncSubmitBlock(ctx, blk); err == nil {
+			return nil
+		} else {
+			lastErr = err
+		}
+	}
+
+	return xerrors.Errorf("all upstream nodes failed SyncSubmitBlock: %w", lastErr)
+}
+
+// Name reports the upstream that would currently be tried first, without
+// consuming a round-robin turn.
+func (r *RoundRobin) Name() string {
+	if len(r.ups) == 0 {
+		return ""
+	}
+
+	ordered := r.orderFrom(atomic.LoadUint64(&r.idx) + 1)
+	return ordered[0].Name
+}
+
+var _ NodeProvider = (*RoundRobin)(nil)
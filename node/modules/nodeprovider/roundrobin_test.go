@@ -0,0 +1,88 @@
+package nodeprovider
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var errUnreachable = xerrors.New("unreachable")
+
+type fakeNode struct {
+	name    string
+	headErr error
+}
+
+func (f *fakeNode) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	if f.headErr != nil {
+		return nil, f.headErr
+	}
+	return &types.TipSet{}, nil
+}
+
+func (f *fakeNode) MinerGetBaseInfo(ctx context.Context, maddr address.Address, epoch abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) {
+	return nil, nil
+}
+
+func (f *fakeNode) StateMinerInfo(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error) {
+	return types.MinerInfo{}, nil
+}
+
+func (f *fakeNode) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
+	return network.Version0, nil
+}
+
+func (f *fakeNode) MpoolSelect(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) ([]*types.SignedMessage, error) {
+	return nil, nil
+}
+
+func (f *fakeNode) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error {
+	return nil
+}
+
+func (f *fakeNode) Name() string {
+	return f.name
+}
+
+func TestHealthCheckSkipsUnhealthyUpstream(t *testing.T) {
+	bad := &fakeNode{name: "bad", headErr: errUnreachable}
+	good := &fakeNode{name: "good"}
+
+	rr := NewRoundRobin([]Upstream{
+		{Name: "bad", API: bad},
+		{Name: "good", API: good},
+	})
+
+	rr.HealthCheck(context.Background())
+
+	if name := rr.Name(); name != "good" {
+		t.Fatalf("expected the healthy upstream to be tried first, got %q", name)
+	}
+
+	if _, err := rr.ChainHead(context.Background()); err != nil {
+		t.Fatalf("expected ChainHead to fail over to the healthy upstream: %s", err)
+	}
+}
+
+func TestNameDoesNotConsumeATurn(t *testing.T) {
+	a := &fakeNode{name: "a"}
+	b := &fakeNode{name: "b"}
+
+	rr := NewRoundRobin([]Upstream{
+		{Name: "a", API: a},
+		{Name: "b", API: b},
+	})
+
+	first := rr.Name()
+	second := rr.Name()
+
+	if first != second {
+		t.Fatalf("Name() should be idempotent, got %q then %q", first, second)
+	}
+}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"golang.org/x/xerrors"
+)
+
+// Dispatcher fans WalletHas/WalletList/WalletSign out across an ordered
+// list of backends. WalletHas and WalletSign stop at the first backend that
+// can serve the request; WalletList returns the addresses of every backend
+// that answers, without duplicates. This lets an operator migrate keys between backends, or run redundant
+// signers, without restarting the miner.
+type Dispatcher struct {
+	backends []Wallet
+}
+
+func NewDispatcher(backends ...Wallet) *Dispatcher {
+	return &Dispatcher{backends: backends}
+}
+
+func (d *Dispatcher) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
+	for _, b := range d.backends {
+		has, err := b.WalletHas(ctx, addr)
+		if err != nil {
+			continue
+		}
+
+		if has {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (d *Dispatcher) WalletList(ctx context.Context) ([]address.Address, error) {
+	seen := make(map[address.Address]struct{})
+	var out []address.Address
+
+	for _, b := range d.backends {
+		addrs, err := b.WalletList(ctx)
+		if err != nil {
+			continue
+		}
+
+		for _, a := range addrs {
+			if _, ok := seen[a]; ok {
+				continue
+			}
+
+			seen[a] = struct{}{}
+			out = append(out, a)
+		}
+	}
+
+	return out, nil
+}
+
+// WalletSign tries every backend that reports holding addr, in order, so a
+// redundant signer still gets used if an earlier backend holding the same
+// key is down.
+func (d *Dispatcher) WalletSign(ctx context.Context, addr address.Address, msg []byte) (*crypto.Signature, error) {
+	var lastErr error
+
+	for _, b := range d.backends {
+		has, err := b.WalletHas(ctx, addr)
+		if err != nil || !has {
+			continue
+		}
+
+		sig, err := b.WalletSign(ctx, addr, msg)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return sig, nil
+	}
+
+	if lastErr != nil {
+		return nil, xerrors.Errorf("no configured wallet backend could sign for %s, last error: %w", addr, lastErr)
+	}
+
+	return nil, xerrors.Errorf("no configured wallet backend holds key for %s", addr)
+}
+
+var _ Wallet = (*Dispatcher)(nil)
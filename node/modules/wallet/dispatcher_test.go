@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+type fakeBackend struct {
+	has     bool
+	hasErr  error
+	sig     *crypto.Signature
+	signErr error
+}
+
+func (f *fakeBackend) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
+	return f.has, f.hasErr
+}
+
+func (f *fakeBackend) WalletList(ctx context.Context) ([]address.Address, error) {
+	return nil, nil
+}
+
+func (f *fakeBackend) WalletSign(ctx context.Context, addr address.Address, msg []byte) (*crypto.Signature, error) {
+	return f.sig, f.signErr
+}
+
+func TestWalletSignFailsOverToNextBackendHoldingKey(t *testing.T) {
+	addr := address.Undef
+	want := &crypto.Signature{}
+
+	down := &fakeBackend{has: true, signErr: xerrors.New("backend unreachable")}
+	up := &fakeBackend{has: true, sig: want}
+
+	d := NewDispatcher(down, up)
+
+	got, err := d.WalletSign(context.Background(), addr, []byte("msg"))
+	if err != nil {
+		t.Fatalf("expected WalletSign to fail over to the second backend, got error: %s", err)
+	}
+
+	if got != want {
+		t.Fatalf("expected the signature from the backend that actually succeeded")
+	}
+}
+
+func TestWalletSignSkipsBackendsThatDoNotHoldKey(t *testing.T) {
+	addr := address.Undef
+	want := &crypto.Signature{}
+
+	noKey := &fakeBackend{has: false}
+	holder := &fakeBackend{has: true, sig: want}
+
+	d := NewDispatcher(noKey, holder)
+
+	got, err := d.WalletSign(context.Background(), addr, []byte("msg"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got != want {
+		t.Fatalf("expected the signature from the backend that holds the key")
+	}
+}
@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-miner/node/modules/dtypes"
+)
+
+// remoteAPI is the subset of the venus-wallet/lotus-wallet JSON-RPC API a
+// remote backend needs.
+type remoteAPI struct {
+	WalletHas  func(ctx context.Context, addr address.Address) (bool, error)
+	WalletList func(ctx context.Context) ([]address.Address, error)
+	WalletSign func(ctx context.Context, addr address.Address, msg []byte) (*crypto.Signature, error)
+}
+
+type remote struct {
+	api    remoteAPI
+	closer jsonrpc.ClientCloser
+}
+
+// DialRemote connects to a venus-wallet/lotus-wallet style JSON-RPC
+// endpoint and returns it wrapped as a Wallet backend, plus a closer the
+// caller must invoke once the backend is no longer in use.
+func DialRemote(ctx context.Context, info dtypes.APIInfo) (Wallet, jsonrpc.ClientCloser, error) {
+	var rapi remoteAPI
+
+	headers := map[string][]string{}
+	if info.Token != "" {
+		headers["Authorization"] = []string{"Bearer " + info.Token}
+	}
+
+	closer, err := jsonrpc.NewMergeClient(ctx, info.ListenAPI, "Filecoin", []interface{}{&rapi}, headers)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("dialing remote wallet %s: %w", info.ListenAPI, err)
+	}
+
+	return &remote{api: rapi, closer: closer}, closer, nil
+}
+
+func (r *remote) WalletHas(ctx context.Context, addr address.Address) (bool, error) {
+	return r.api.WalletHas(ctx, addr)
+}
+
+func (r *remote) WalletList(ctx context.Context) ([]address.Address, error) {
+	return r.api.WalletList(ctx)
+}
+
+func (r *remote) WalletSign(ctx context.Context, addr address.Address, msg []byte) (*crypto.Signature, error) {
+	return r.api.WalletSign(ctx, addr, msg)
+}
+
+var _ Wallet = (*remote)(nil)
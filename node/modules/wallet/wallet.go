@@ -0,0 +1,17 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// Wallet is implemented by the Dispatcher and by the signing backends it
+// dispatches to. The only backend implementation is the remote
+// venus-wallet/lotus-wallet JSON-RPC client returned by DialRemote.
+type Wallet interface {
+	WalletHas(ctx context.Context, addr address.Address) (bool, error)
+	WalletList(ctx context.Context) ([]address.Address, error)
+	WalletSign(ctx context.Context, addr address.Address, msg []byte) (*crypto.Signature, error)
+}